@@ -0,0 +1,31 @@
+// Command mcp-stdio запускает go-biz-engine как MCP-сервер поверх stdio,
+// чтобы Claude Desktop и другие MCP-хосты могли вызывать tools.Registry
+// напрямую, без HTTP. HTTP-сервер (cmd/server) при этом продолжает работать
+// как отдельный, независимый способ обращения к тем же инструментам.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go-biz-engine/internal/config"
+	"go-biz-engine/internal/mcp"
+	"go-biz-engine/internal/tools"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "config:", err)
+		os.Exit(1)
+	}
+
+	registry := tools.NewDefaultRegistry(cfg)
+	server := mcp.NewServer(registry, cfg.Version)
+
+	if err := server.Serve(context.Background(), os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "mcp-stdio:", err)
+		os.Exit(1)
+	}
+}
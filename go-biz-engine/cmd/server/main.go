@@ -0,0 +1,27 @@
+// Command server запускает HTTP API go-biz-engine.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go-biz-engine/internal/config"
+	bizhttp "go-biz-engine/internal/http"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "config:", err)
+		os.Exit(1)
+	}
+
+	handler := bizhttp.NewHandler(cfg)
+	server := bizhttp.NewServer(cfg, handler.Router())
+
+	if err := server.Run(context.Background()); err != nil {
+		fmt.Fprintln(os.Stderr, "server:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,87 @@
+// Package cache реализует простой in-process TTL-кэш с коалесацией
+// конкурентных обращений (singleflight), используемый для снижения
+// трафика к апстрим-провайдерам котировок.
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+type entry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// Cache — TTL-кэш, ключуемый произвольной строкой (обычно URL апстрима).
+// Безопасен для конкурентного использования.
+type Cache struct {
+	ttl   time.Duration
+	mu    sync.RWMutex
+	items map[string]entry
+	group singleflight.Group
+}
+
+// New создаёт кэш с заданным TTL. ttl <= 0 отключает кэширование: GetOrLoad
+// по-прежнему коалесцирует конкурентные вызовы, но ничего не переживает между ними.
+func New(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:   ttl,
+		items: make(map[string]entry),
+	}
+}
+
+func (c *Cache) get(key string) (interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.items[key]
+	if !ok || time.Now().After(e.expiresAt) {
+		return nil, false
+	}
+	return e.value, true
+}
+
+func (c *Cache) set(key string, value interface{}) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[key] = entry{value: value, expiresAt: time.Now().Add(c.ttl)}
+}
+
+// GetOrLoad возвращает значение по key из кэша, либо выполняет load ровно
+// один раз на группу одновременных вызовов с одинаковым key (singleflight),
+// кладёт результат в кэш и возвращает его. hit=true означает, что этот вызов
+// сам load не выполнял: значение взято из кэша либо получено от "ведущего"
+// вызова той же группы, который и выполнил load.
+func (c *Cache) GetOrLoad(key string, load func() (interface{}, error)) (value interface{}, hit bool, err error) {
+	if v, ok := c.get(key); ok {
+		return v, true, nil
+	}
+
+	loaded := false
+	v, err, _ := c.group.Do(key, func() (interface{}, error) {
+		if v, ok := c.get(key); ok {
+			return v, nil
+		}
+		v, err := load()
+		if err != nil {
+			return nil, err
+		}
+		loaded = true
+		c.set(key, v)
+		return v, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	// loaded=false значит, что кто-то другой в группе уже сходил за значением
+	// (либо оно уже появилось в кэше), поэтому для счётчика апстрим-вызовов
+	// это тоже hit.
+	return v, !loaded, nil
+}
@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCache_GetOrLoad_CachesWithinTTL(t *testing.T) {
+	c := New(time.Hour)
+
+	var loads int64
+	load := func() (interface{}, error) {
+		atomic.AddInt64(&loads, 1)
+		return "value", nil
+	}
+
+	v, hit, err := c.GetOrLoad("key", load)
+	if err != nil || hit || v != "value" {
+		t.Fatalf("first call: v=%v hit=%v err=%v, want value=value hit=false err=nil", v, hit, err)
+	}
+
+	v, hit, err = c.GetOrLoad("key", load)
+	if err != nil || !hit || v != "value" {
+		t.Fatalf("second call: v=%v hit=%v err=%v, want value=value hit=true err=nil", v, hit, err)
+	}
+
+	if loads != 1 {
+		t.Fatalf("load called %d times, want 1", loads)
+	}
+}
+
+func TestCache_GetOrLoad_ExpiresAfterTTL(t *testing.T) {
+	c := New(10 * time.Millisecond)
+
+	var loads int64
+	load := func() (interface{}, error) {
+		atomic.AddInt64(&loads, 1)
+		return "value", nil
+	}
+
+	if _, _, err := c.GetOrLoad("key", load); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, _, err := c.GetOrLoad("key", load); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if loads != 2 {
+		t.Fatalf("load called %d times after TTL expiry, want 2", loads)
+	}
+}
+
+func TestCache_GetOrLoad_PropagatesLoadError(t *testing.T) {
+	c := New(time.Hour)
+	wantErr := errors.New("upstream unavailable")
+
+	_, _, err := c.GetOrLoad("key", func() (interface{}, error) {
+		return nil, wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("err = %v, want %v", err, wantErr)
+	}
+
+	// Ошибка не должна быть закэширована — следующий вызов обязан снова
+	// вызвать load и получить шанс на успех.
+	v, _, err := c.GetOrLoad("key", func() (interface{}, error) {
+		return "recovered", nil
+	})
+	if err != nil || v != "recovered" {
+		t.Fatalf("v=%v err=%v, want value=recovered err=nil", v, err)
+	}
+}
+
+func TestCache_GetOrLoad_CoalescesConcurrentCalls(t *testing.T) {
+	c := New(time.Hour)
+
+	var loads int64
+	const goroutines = 20
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			_, _, err := c.GetOrLoad("key", func() (interface{}, error) {
+				atomic.AddInt64(&loads, 1)
+				time.Sleep(10 * time.Millisecond)
+				return "value", nil
+			})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if loads != 1 {
+		t.Fatalf("load called %d times for concurrent callers, want 1 (singleflight)", loads)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io/fs"
 	"os"
 	"strings"
+	"time"
 )
 
 type Config struct {
@@ -15,6 +16,20 @@ type Config struct {
 	APIKey       string
 	Env          string // например: dev / prod
 	Version      string // версия сервиса
+
+	AlpacaAPIKeyID     string // ALPACA_API_KEY_ID, для провайдера "alpaca" в financial_analyzer
+	AlpacaAPISecretKey string // ALPACA_API_SECRET_KEY
+
+	RateCacheTTL time.Duration // BIZ_ENGINE_RATE_CACHE_TTL, TTL кэша апстрим-котировок
+
+	ReadHeaderTimeout time.Duration // BIZ_ENGINE_READ_HEADER_TIMEOUT
+	ReadTimeout       time.Duration // BIZ_ENGINE_READ_TIMEOUT
+	WriteTimeout      time.Duration // BIZ_ENGINE_WRITE_TIMEOUT
+	IdleTimeout       time.Duration // BIZ_ENGINE_IDLE_TIMEOUT
+	ShutdownTimeout   time.Duration // BIZ_ENGINE_SHUTDOWN_TIMEOUT, дедлайн на дренаж при graceful shutdown
+
+	ToolTimeout     time.Duration // BIZ_ENGINE_TOOL_TIMEOUT, дедлайн на выполнение одного /execute-tool
+	UpstreamTimeout time.Duration // BIZ_ENGINE_UPSTREAM_TIMEOUT, таймаут HTTP-клиента к апстрим-провайдерам
 }
 
 // Load загружает .env (если есть) и читает переменные окружения.
@@ -27,6 +42,20 @@ func Load() (*Config, error) {
 		APIKey:       getEnv("BIZ_ENGINE_API_KEY", ""),
 		Env:          getEnv("BIZ_ENGINE_ENV", "dev"),
 		Version:      getEnv("BIZ_ENGINE_VERSION", "go-biz-engine/0.1.0"),
+
+		AlpacaAPIKeyID:     getEnv("ALPACA_API_KEY_ID", ""),
+		AlpacaAPISecretKey: getEnv("ALPACA_API_SECRET_KEY", ""),
+
+		RateCacheTTL: getEnvDuration("BIZ_ENGINE_RATE_CACHE_TTL", 5*time.Minute),
+
+		ReadHeaderTimeout: getEnvDuration("BIZ_ENGINE_READ_HEADER_TIMEOUT", 5*time.Second),
+		ReadTimeout:       getEnvDuration("BIZ_ENGINE_READ_TIMEOUT", 15*time.Second),
+		WriteTimeout:      getEnvDuration("BIZ_ENGINE_WRITE_TIMEOUT", 15*time.Second),
+		IdleTimeout:       getEnvDuration("BIZ_ENGINE_IDLE_TIMEOUT", 60*time.Second),
+		ShutdownTimeout:   getEnvDuration("BIZ_ENGINE_SHUTDOWN_TIMEOUT", 10*time.Second),
+
+		ToolTimeout:     getEnvDuration("BIZ_ENGINE_TOOL_TIMEOUT", 20*time.Second),
+		UpstreamTimeout: getEnvDuration("BIZ_ENGINE_UPSTREAM_TIMEOUT", 10*time.Second),
 	}
 
 	if cfg.PublicAPIURL == "" {
@@ -74,3 +103,17 @@ func getEnv(key, def string) string {
 	}
 	return def
 }
+
+// getEnvDuration разбирает переменную окружения как time.Duration (например
+// "5m", "30s"); при отсутствии или некорректном значении возвращает def.
+func getEnvDuration(key string, def time.Duration) time.Duration {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return def
+	}
+	return d
+}
@@ -1,9 +1,10 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
-	"log"
 	"net/http"
+	"time"
 
 	"go-biz-engine/internal/tools"
 )
@@ -34,6 +35,47 @@ func (h *Handler) handleHealth(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
+// toolDescriptor — элемент ответа GET /tools: имя + JSON Schema параметров,
+// чтобы LLM/MCP-клиенты могли обнаружить инструмент и его контракт.
+type toolDescriptor struct {
+	Name         string          `json:"name"`
+	ParamsSchema json.RawMessage `json:"params_schema"`
+}
+
+func (h *Handler) handleListTools(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	list := h.registry.List()
+	out := make([]toolDescriptor, 0, len(list))
+	for _, t := range list {
+		out = append(out, toolDescriptor{
+			Name:         t.Name(),
+			ParamsSchema: t.ParamsSchema(),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+// metricsToolLabel ограничивает множество значений label "tool" для
+// toolExecutionsTotal/toolLatencySeconds зарегистрированными инструментами:
+// req.ToolName приходит из клиентского JSON и до этого места ещё не
+// проверялся registry (это происходит только внутри tools.ExecuteTool), так
+// что без этой проверки клиент мог бы раздувать кардинальность метрик
+// произвольными значениями tool_name — CounterVec/HistogramVec никогда не
+// вытесняют старые label-комбинации.
+func (h *Handler) metricsToolLabel(name string) string {
+	if _, ok := h.registry.Get(name); ok {
+		return name
+	}
+	return "unknown"
+}
+
 func (h *Handler) handleExecuteTool(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		w.Header().Set("Allow", http.MethodPost)
@@ -62,9 +104,31 @@ func (h *Handler) handleExecuteTool(w http.ResponseWriter, r *http.Request) {
 		req.Params = make(map[string]interface{})
 	}
 
-	resp, err := tools.ExecuteTool(r.Context(), req, h.cfg.Version)
+	setToolName(r.Context(), req.ToolName)
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.ToolTimeout)
+	defer cancel()
+
+	upstreamCalls := tools.NewUpstreamCallRecorder()
+	ctx = tools.ContextWithUpstreamCallRecorder(ctx, upstreamCalls)
+
+	toolLabel := h.metricsToolLabel(req.ToolName)
+
+	start := time.Now()
+	resp, err := tools.ExecuteTool(ctx, req, h.cfg.Version, h.registry)
+	toolLatencySeconds.WithLabelValues(toolLabel).Observe(time.Since(start).Seconds())
+	for provider, n := range upstreamCalls.ByProvider() {
+		upstreamCallsTotal.WithLabelValues(provider).Add(float64(n))
+	}
+
 	if err != nil {
-		log.Printf("ExecuteTool error: %v", err)
+		h.logger.Error().
+			Str("request_id", requestIDFromContext(ctx)).
+			Str("tool_name", req.ToolName).
+			Err(err).
+			Msg("ExecuteTool error")
+
+		toolExecutionsTotal.WithLabelValues(toolLabel, "error").Inc()
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
@@ -79,6 +143,8 @@ func (h *Handler) handleExecuteTool(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	toolExecutionsTotal.WithLabelValues(toolLabel, resp.Status).Inc()
+
 	w.Header().Set("Content-Type", "application/json")
 	// здесь по контракту обычно 200 OK, даже если resp.Status == "error"
 	_ = json.NewEncoder(w).Encode(resp)
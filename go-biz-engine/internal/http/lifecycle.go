@@ -0,0 +1,66 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go-biz-engine/internal/config"
+)
+
+// Server оборачивает http.Server таймаутами из конфига и сигнал-драйвенным
+// graceful shutdown, чтобы cmd/server мог просто вызвать Run.
+type Server struct {
+	httpServer      *http.Server
+	shutdownTimeout time.Duration
+}
+
+// NewServer строит Server с таймаутами из cfg поверх переданного handler.
+func NewServer(cfg *config.Config, handler http.Handler) *Server {
+	return &Server{
+		httpServer: &http.Server{
+			Addr:              ":" + cfg.Port,
+			Handler:           handler,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			ReadTimeout:       cfg.ReadTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+		},
+		shutdownTimeout: cfg.ShutdownTimeout,
+	}
+}
+
+// Run запускает HTTP-сервер и блокируется, пока ctx не отменится или не
+// придёт SIGINT/SIGTERM, после чего делает graceful shutdown с дедлайном
+// shutdownTimeout на дренаж уже принятых соединений.
+func (s *Server) Run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+			return
+		}
+		serveErr <- nil
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("graceful shutdown: %w", err)
+	}
+	return nil
+}
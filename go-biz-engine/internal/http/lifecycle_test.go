@@ -0,0 +1,37 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-biz-engine/internal/config"
+)
+
+func TestServer_Run_GracefulShutdownOnContextCancel(t *testing.T) {
+	cfg := &config.Config{
+		Port:            "0", // ephemeral port, не конфликтует с параллельными тестами
+		ShutdownTimeout: time.Second,
+	}
+
+	srv := NewServer(cfg, http.NewServeMux())
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() { done <- srv.Run(ctx) }()
+
+	// даём ListenAndServe время подняться, затем просим graceful shutdown
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run() = %v, want nil after graceful shutdown", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return within shutdownTimeout after ctx cancel")
+	}
+}
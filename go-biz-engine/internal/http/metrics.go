@@ -0,0 +1,36 @@
+package http
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Метрики, экспортируемые на GET /metrics. Регистрируются один раз в
+// DefaultRegisterer при импорте пакета.
+var (
+	toolExecutionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "biz_engine_tool_executions_total",
+			Help: "Количество выполнений инструментов, по имени инструмента и статусу.",
+		},
+		[]string{"tool", "status"},
+	)
+
+	toolLatencySeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "biz_engine_tool_latency_seconds",
+			Help:    "Латентность выполнения инструмента в секундах.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"tool"},
+	)
+
+	upstreamCallsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "biz_engine_upstream_calls_total",
+			Help: "Количество реальных (не из internal/cache) обращений к апстрим-провайдерам.",
+		},
+		[]string{"provider"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(toolExecutionsTotal, toolLatencySeconds, upstreamCallsTotal)
+}
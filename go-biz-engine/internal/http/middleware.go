@@ -0,0 +1,90 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type ctxKey int
+
+const (
+	requestIDCtxKey ctxKey = iota
+	toolNameCtxKey
+)
+
+// requestIDFromContext достаёт request id, проставленный requestIDMiddleware.
+func requestIDFromContext(ctx context.Context) string {
+	if v, ok := ctx.Value(requestIDCtxKey).(*string); ok {
+		return *v
+	}
+	return ""
+}
+
+// setToolName кладёт имя вызванного инструмента в ctx, чтобы loggingMiddleware
+// мог залогировать его вместе с методом/путём/статусом после завершения запроса.
+func setToolName(ctx context.Context, name string) {
+	if v, ok := ctx.Value(toolNameCtxKey).(*string); ok {
+		*v = name
+	}
+}
+
+// requestIDMiddleware берёт request id из X-Request-ID, если он пришёл от
+// клиента, иначе генерирует UUID, кладёт его в ctx и эхом возвращает в
+// заголовке ответа.
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("X-Request-ID")
+		if id == "" {
+			id = uuid.NewString()
+		}
+
+		ctx := context.WithValue(r.Context(), requestIDCtxKey, &id)
+		w.Header().Set("X-Request-ID", id)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// statusRecorder перехватывает код статуса ответа для access-лога.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusRecorder) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// loggingMiddleware логирует каждый запрос одной JSON-строкой через zerolog:
+// метод, путь, статус, задержку, request_id и (если его проставил handler)
+// имя вызванного инструмента.
+func loggingMiddleware(logger zerolog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		var toolName string
+		ctx := context.WithValue(r.Context(), toolNameCtxKey, &toolName)
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		event := logger.Info()
+		if rec.status >= http.StatusInternalServerError {
+			event = logger.Error()
+		}
+
+		event.
+			Str("request_id", requestIDFromContext(r.Context())).
+			Str("method", r.Method).
+			Str("path", r.URL.Path).
+			Int("status", rec.status).
+			Dur("latency", time.Since(start)).
+			Str("tool_name", toolName).
+			Msg("http request")
+	})
+}
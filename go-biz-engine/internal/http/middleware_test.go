@@ -0,0 +1,80 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestRequestIDMiddleware_GeneratesIDWhenMissing(t *testing.T) {
+	var gotID string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID = requestIDFromContext(r.Context())
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+
+	requestIDMiddleware(next).ServeHTTP(rec, req)
+
+	if gotID == "" {
+		t.Fatal("requestIDFromContext returned empty id, want a generated UUID")
+	}
+	if rec.Header().Get("X-Request-ID") != gotID {
+		t.Errorf("X-Request-ID header = %q, want %q", rec.Header().Get("X-Request-ID"), gotID)
+	}
+}
+
+func TestRequestIDMiddleware_EchoesClientID(t *testing.T) {
+	var gotID string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID = requestIDFromContext(r.Context())
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("X-Request-ID", "client-supplied-id")
+
+	requestIDMiddleware(next).ServeHTTP(rec, req)
+
+	if gotID != "client-supplied-id" {
+		t.Errorf("gotID = %q, want client-supplied-id", gotID)
+	}
+	if rec.Header().Get("X-Request-ID") != "client-supplied-id" {
+		t.Errorf("X-Request-ID header = %q, want client-supplied-id", rec.Header().Get("X-Request-ID"))
+	}
+}
+
+func TestLoggingMiddleware_LogsStatusAndToolName(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		setToolName(r.Context(), "financial_analyzer")
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/execute-tool", nil)
+
+	loggingMiddleware(logger, next).ServeHTTP(rec, req)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("log line is not valid JSON: %v (%q)", err, buf.String())
+	}
+
+	if entry["level"] != "error" {
+		t.Errorf("level = %v, want error (status >= 500)", entry["level"])
+	}
+	if entry["status"] != float64(http.StatusInternalServerError) {
+		t.Errorf("status = %v, want %d", entry["status"], http.StatusInternalServerError)
+	}
+	if entry["tool_name"] != "financial_analyzer" {
+		t.Errorf("tool_name = %v, want financial_analyzer", entry["tool_name"])
+	}
+}
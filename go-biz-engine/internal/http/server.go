@@ -2,26 +2,48 @@ package http
 
 import (
 	"net/http"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog"
 
 	"go-biz-engine/internal/config"
+	"go-biz-engine/internal/tools"
 )
 
-// Handler хранит зависимости (config и т.д.)
+// Handler хранит зависимости (config, реестр инструментов, логгер и т.д.)
 type Handler struct {
-	cfg *config.Config
+	cfg      *config.Config
+	registry *tools.Registry
+	logger   zerolog.Logger
 }
 
-// NewHandler создаёт HTTP-хендлер с конфигом.
+// NewHandler создаёт HTTP-хендлер с конфигом и реестром инструментов по умолчанию.
 func NewHandler(cfg *config.Config) *Handler {
-	return &Handler{cfg: cfg}
+	logger := zerolog.New(os.Stdout).With().Timestamp().Str("service", "go-biz-engine").Logger()
+
+	return &Handler{
+		cfg:      cfg,
+		registry: tools.NewDefaultRegistry(cfg),
+		logger:   logger,
+	}
 }
 
-// Router регистрирует маршруты и возвращает http.Handler.
+// Router регистрирует маршруты и оборачивает их access-log и request-id
+// middleware. /metrics отдаётся без них — это внутренний эндпойнт для
+// scrape-запросов Prometheus, а не клиентский трафик.
 func (h *Handler) Router() http.Handler {
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/health", h.handleHealth)
+	mux.HandleFunc("/tools", h.handleListTools)
 	mux.HandleFunc("/execute-tool", h.handleExecuteTool)
 
-	return mux
+	wrapped := requestIDMiddleware(loggingMiddleware(h.logger, mux))
+
+	root := http.NewServeMux()
+	root.Handle("/metrics", promhttp.Handler())
+	root.Handle("/", wrapped)
+
+	return root
 }
@@ -0,0 +1,181 @@
+// Package mcp реализует транспорт Model Context Protocol поверх stdio:
+// JSON-RPC 2.0 сообщения, разделённые переводом строки, handshake
+// initialize/notifications/initialized и методы tools/list, tools/call
+// поверх того же tools.Registry, что отдаёт HTTP /tools и /execute-tool.
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"go-biz-engine/internal/tools"
+)
+
+// Server обслуживает JSON-RPC 2.0 запросы MCP-хоста, транслируя их в вызовы
+// tools.Registry/tools.ExecuteTool.
+type Server struct {
+	registry      *tools.Registry
+	engineVersion string
+}
+
+// NewServer создаёт MCP-сервер поверх registry. engineVersion пробрасывается
+// в ExecuteResponse.EngineVersion так же, как это делает HTTP-хендлер.
+func NewServer(registry *tools.Registry, engineVersion string) *Server {
+	return &Server{registry: registry, engineVersion: engineVersion}
+}
+
+// Serve читает newline-delimited JSON-RPC запросы из r и пишет ответы в w,
+// пока r не отдаст EOF, не случится ошибка записи или не отменится ctx.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	enc := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var req Request
+		if err := json.Unmarshal(line, &req); err != nil {
+			_ = enc.Encode(Response{
+				JSONRPC: "2.0",
+				Error:   &RPCError{Code: codeParseError, Message: "parse error: " + err.Error()},
+			})
+			continue
+		}
+
+		resp := s.handle(ctx, req)
+		if req.ID == nil {
+			// JSON-RPC 2.0 нотификация (например notifications/initialized) —
+			// хост не ждёт ответа, отвечать нельзя.
+			continue
+		}
+
+		if err := enc.Encode(resp); err != nil {
+			return fmt.Errorf("write mcp response: %w", err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+func (s *Server) handle(ctx context.Context, req Request) Response {
+	resp := Response{JSONRPC: "2.0", ID: req.ID}
+
+	switch req.Method {
+	case "initialize":
+		resp.Result = s.initialize()
+	case "notifications/initialized":
+		// хост подтверждает завершение handshake; реагировать нечем.
+	case "tools/list":
+		resp.Result = s.toolsList()
+	case "tools/call":
+		result, rpcErr := s.toolsCall(ctx, req.Params)
+		if rpcErr != nil {
+			resp.Error = rpcErr
+		} else {
+			resp.Result = result
+		}
+	default:
+		resp.Error = &RPCError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+
+	return resp
+}
+
+// initialize отвечает на handshake MCP: хост (Claude Desktop и т.п.) шлёт
+// initialize перед первым tools/list и ждёт protocolVersion/capabilities,
+// чтобы согласовать, что сервер умеет.
+func (s *Server) initialize() map[string]interface{} {
+	return map[string]interface{}{
+		"protocolVersion": mcpProtocolVersion,
+		"capabilities": map[string]interface{}{
+			"tools": map[string]interface{}{},
+		},
+		"serverInfo": map[string]interface{}{
+			"name":    "go-biz-engine",
+			"version": s.engineVersion,
+		},
+	}
+}
+
+func (s *Server) toolsList() map[string]interface{} {
+	list := s.registry.List()
+
+	descriptors := make([]toolDescriptor, 0, len(list))
+	for _, t := range list {
+		descriptors = append(descriptors, toolDescriptor{
+			Name:        t.Name(),
+			InputSchema: t.ParamsSchema(),
+		})
+	}
+
+	return map[string]interface{}{"tools": descriptors}
+}
+
+func (s *Server) toolsCall(ctx context.Context, rawParams json.RawMessage) (*callResult, *RPCError) {
+	var params callParams
+	if err := json.Unmarshal(rawParams, &params); err != nil {
+		return nil, &RPCError{Code: codeInvalidParams, Message: "invalid tools/call params: " + err.Error()}
+	}
+	if params.Name == "" {
+		return nil, &RPCError{Code: codeInvalidParams, Message: "params.name is required"}
+	}
+	if params.Arguments == nil {
+		params.Arguments = make(map[string]interface{})
+	}
+
+	execReq := tools.ExecuteRequest{ToolName: params.Name, Params: params.Arguments}
+
+	execResp, err := tools.ExecuteTool(ctx, execReq, s.engineVersion, s.registry)
+	if err != nil {
+		return nil, &RPCError{Code: codeInternal, Message: err.Error()}
+	}
+	if execResp.Status == "error" {
+		return nil, errorInfoToRPCError(execResp.Error)
+	}
+
+	payload, err := json.Marshal(execResp.Data)
+	if err != nil {
+		return nil, &RPCError{Code: codeInternal, Message: "marshal tool result: " + err.Error()}
+	}
+
+	return &callResult{Content: []contentBlock{{Type: "text", Text: string(payload)}}}, nil
+}
+
+// errorInfoToRPCError переводит бизнес-ошибку ExecuteResponse.Error в
+// JSON-RPC ошибку со стабильным кодом: неизвестный инструмент — код
+// "method not found", ошибка валидации параметров — "invalid params",
+// всё остальное — общий internal-код с исходным кодом/деталями в data,
+// чтобы MCP-хост не терял диагностику.
+func errorInfoToRPCError(e *tools.ErrorInfo) *RPCError {
+	if e == nil {
+		return &RPCError{Code: codeInternal, Message: "unknown error"}
+	}
+
+	switch e.Code {
+	case "UNKNOWN_TOOL":
+		return &RPCError{Code: codeUnknownTool, Message: e.Message}
+	case "INVALID_PARAMS":
+		return &RPCError{Code: codeInvalidParams, Message: e.Message}
+	default:
+		return &RPCError{
+			Code:    codeInternal,
+			Message: e.Message,
+			Data:    map[string]interface{}{"code": e.Code, "details": e.Details},
+		}
+	}
+}
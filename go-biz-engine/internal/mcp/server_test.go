@@ -0,0 +1,158 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"go-biz-engine/internal/tools"
+)
+
+// stubTool — минимальный tools.Tool для тестов транспорта, не трогающий
+// реальные провайдеры.
+type stubTool struct {
+	name string
+	err  error
+}
+
+func (t *stubTool) Name() string                 { return t.name }
+func (t *stubTool) ParamsSchema() json.RawMessage { return json.RawMessage(`{}`) }
+func (t *stubTool) Execute(ctx context.Context, rawParams map[string]interface{}) (interface{}, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	return map[string]interface{}{"ok": true}, nil
+}
+
+func newTestServer(tool *stubTool) *Server {
+	registry := tools.NewRegistry()
+	registry.Register(tool)
+	return NewServer(registry, "test")
+}
+
+func TestServer_Handle_Initialize(t *testing.T) {
+	s := newTestServer(&stubTool{name: "stub"})
+
+	resp := s.handle(context.Background(), Request{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "initialize"})
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %+v", resp.Error)
+	}
+
+	result, ok := resp.Result.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Result = %#v, want map[string]interface{}", resp.Result)
+	}
+	if result["protocolVersion"] != mcpProtocolVersion {
+		t.Errorf("protocolVersion = %v, want %v", result["protocolVersion"], mcpProtocolVersion)
+	}
+	if _, ok := result["capabilities"]; !ok {
+		t.Error("result missing capabilities")
+	}
+	if _, ok := result["serverInfo"]; !ok {
+		t.Error("result missing serverInfo")
+	}
+}
+
+func TestServer_Handle_UnknownTool(t *testing.T) {
+	s := newTestServer(&stubTool{name: "stub"})
+
+	params, _ := json.Marshal(callParams{Name: "missing"})
+	resp := s.handle(context.Background(), Request{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "tools/call", Params: params})
+
+	if resp.Error == nil {
+		t.Fatal("expected error for unknown tool, got nil")
+	}
+	if resp.Error.Code != codeUnknownTool {
+		t.Errorf("Error.Code = %d, want %d (method not found)", resp.Error.Code, codeUnknownTool)
+	}
+}
+
+func TestServer_Handle_InvalidParams(t *testing.T) {
+	s := newTestServer(&stubTool{name: "stub", err: tools.NewInvalidParamsError(errors.New("bad field"))})
+
+	params, _ := json.Marshal(callParams{Name: "stub"})
+	resp := s.handle(context.Background(), Request{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "tools/call", Params: params})
+
+	if resp.Error == nil {
+		t.Fatal("expected error for invalid params, got nil")
+	}
+	if resp.Error.Code != codeInvalidParams {
+		t.Errorf("Error.Code = %d, want %d (invalid params)", resp.Error.Code, codeInvalidParams)
+	}
+}
+
+func TestServer_Handle_ToolsCallSuccess(t *testing.T) {
+	s := newTestServer(&stubTool{name: "stub"})
+
+	params, _ := json.Marshal(callParams{Name: "stub"})
+	resp := s.handle(context.Background(), Request{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "tools/call", Params: params})
+
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %+v", resp.Error)
+	}
+	result, ok := resp.Result.(*callResult)
+	if !ok || len(result.Content) != 1 {
+		t.Fatalf("Result = %#v, want *callResult with one content block", resp.Result)
+	}
+	if !strings.Contains(result.Content[0].Text, `"ok":true`) {
+		t.Errorf("content text = %q, want it to contain the tool payload", result.Content[0].Text)
+	}
+}
+
+func TestServer_Handle_UnknownMethod(t *testing.T) {
+	s := newTestServer(&stubTool{name: "stub"})
+
+	resp := s.handle(context.Background(), Request{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: "bogus/method"})
+	if resp.Error == nil || resp.Error.Code != codeMethodNotFound {
+		t.Fatalf("Error = %+v, want codeMethodNotFound", resp.Error)
+	}
+}
+
+func TestServer_Serve_NotificationGetsNoReply(t *testing.T) {
+	s := newTestServer(&stubTool{name: "stub"})
+
+	in := strings.NewReader(
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}` + "\n" +
+			`{"jsonrpc":"2.0","id":1,"method":"tools/list"}` + "\n",
+	)
+	var out bytes.Buffer
+
+	if err := s.Serve(context.Background(), in, &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("got %d response lines, want 1 (notification must not get a reply): %q", len(lines), out.String())
+	}
+
+	var resp Response
+	if err := json.Unmarshal([]byte(lines[0]), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.Error != nil {
+		t.Fatalf("unexpected error in tools/list response: %+v", resp.Error)
+	}
+}
+
+func TestServer_Serve_ParseError(t *testing.T) {
+	s := newTestServer(&stubTool{name: "stub"})
+
+	in := strings.NewReader("not json\n")
+	var out bytes.Buffer
+
+	if err := s.Serve(context.Background(), in, &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.Error == nil || resp.Error.Code != codeParseError {
+		t.Fatalf("Error = %+v, want codeParseError", resp.Error)
+	}
+}
@@ -0,0 +1,65 @@
+package mcp
+
+import "encoding/json"
+
+// mcpProtocolVersion — версия протокола MCP, которую заявляет сервер в ответ
+// на initialize.
+const mcpProtocolVersion = "2024-11-05"
+
+// Request — JSON-RPC 2.0 запрос, как его присылают MCP-хосты (Claude Desktop
+// и т.п.) по stdio.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response — JSON-RPC 2.0 ответ. Result и Error взаимоисключающие.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// RPCError — ошибка JSON-RPC 2.0.
+type RPCError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// коды ошибок JSON-RPC 2.0, которыми мы отвечаем на tools/call и tools/list.
+const (
+	codeParseError     = -32700
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeUnknownTool    = -32601 // тот же код, что у method-not-found — семантически тоже "неизвестный идентификатор"
+	codeInternal       = -32000
+)
+
+// toolDescriptor — то, как MCP-хост видит зарегистрированный Tool в ответе
+// tools/list.
+type toolDescriptor struct {
+	Name        string          `json:"name"`
+	InputSchema json.RawMessage `json:"inputSchema"`
+}
+
+// callParams — params запроса tools/call.
+type callParams struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+// contentBlock — один блок content в ответе tools/call (формат MCP).
+type contentBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// callResult — result запроса tools/call.
+type callResult struct {
+	Content []contentBlock `json:"content"`
+	IsError bool           `json:"isError,omitempty"`
+}
@@ -0,0 +1,254 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-biz-engine/internal/cache"
+)
+
+const (
+	alpacaDataBaseURL = "https://data.alpaca.markets"
+	alpacaMaxRetries  = 4
+	alpacaMaxPageSize = 1000
+)
+
+// alpacaProvider — RateProvider поверх Alpaca Market Data REST.
+// Работает как с акциями (/v2/stocks/{symbol}/bars), так и с криптой
+// (/v1beta3/crypto/us/bars), определяя рынок по тому, выглядит ли base
+// как крипто-тикер.
+type alpacaProvider struct {
+	keyID      string
+	secretKey  string
+	httpClient *http.Client
+	rateCache  *cache.Cache
+}
+
+func newAlpacaProvider(keyID, secretKey string, httpClient *http.Client, rateCache *cache.Cache) *alpacaProvider {
+	return &alpacaProvider{keyID: keyID, secretKey: secretKey, httpClient: httpClient, rateCache: rateCache}
+}
+
+func (p *alpacaProvider) Name() string {
+	return providerAlpaca
+}
+
+// известные крипто-тикеры Alpaca; по ним base/quote превращается в пару
+// вида "BTC/USD" и уходит на крипто-эндпойнт, иначе считаем base тикером акции.
+var alpacaCryptoSymbols = map[string]bool{
+	"BTC": true, "ETH": true, "LTC": true, "DOGE": true, "SOL": true,
+	"AVAX": true, "LINK": true, "UNI": true, "AAVE": true, "BCH": true,
+}
+
+func (p *alpacaProvider) FetchDailyRates(ctx context.Context, base, quote string, days int) ([]DailyRate, error) {
+	if p.keyID == "" || p.secretKey == "" {
+		return nil, fmt.Errorf("alpaca credentials are not configured (ALPACA_API_KEY_ID / ALPACA_API_SECRET_KEY)")
+	}
+
+	end := time.Now().UTC()
+	start := end.AddDate(0, 0, -days+1)
+
+	// кэшируем по тому же окну (base, quote, days), что и апстрим-запрос
+	cacheKey := fmt.Sprintf("alpaca:%s:%s:%s:%s", base, quote, start.Format("2006-01-02"), end.Format("2006-01-02"))
+
+	v, _, err := p.rateCache.GetOrLoad(cacheKey, func() (interface{}, error) {
+		recordUpstreamCall(ctx, p.Name())
+		if alpacaCryptoSymbols[base] {
+			return p.fetchCryptoBars(ctx, base+"/"+quote, start, end)
+		}
+		return p.fetchStockBars(ctx, base, start, end)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]DailyRate), nil
+}
+
+type alpacaBar struct {
+	Timestamp string  `json:"t"`
+	Close     float64 `json:"c"`
+}
+
+// ответ /v2/stocks/{symbol}/bars
+type alpacaStockBarsResponse struct {
+	Symbol        string      `json:"symbol"`
+	Bars          []alpacaBar `json:"bars"`
+	NextPageToken string      `json:"next_page_token"`
+}
+
+func (p *alpacaProvider) fetchStockBars(ctx context.Context, symbol string, start, end time.Time) ([]DailyRate, error) {
+	base := fmt.Sprintf("%s/v2/stocks/%s/bars", alpacaDataBaseURL, url.PathEscape(symbol))
+
+	var bars []alpacaBar
+	pageToken := ""
+	for {
+		q := url.Values{}
+		q.Set("timeframe", "1Day")
+		q.Set("start", start.Format(time.RFC3339))
+		q.Set("end", end.Format(time.RFC3339))
+		q.Set("limit", strconv.Itoa(alpacaMaxPageSize))
+		if pageToken != "" {
+			q.Set("page_token", pageToken)
+		}
+
+		var page alpacaStockBarsResponse
+		if err := p.getJSON(ctx, base+"?"+q.Encode(), &page); err != nil {
+			return nil, err
+		}
+		bars = append(bars, page.Bars...)
+
+		if page.NextPageToken == "" {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+
+	return barsToDailyRates(symbol, bars)
+}
+
+// ответ /v1beta3/crypto/us/bars — один запрос может покрывать несколько символов,
+// поэтому Bars — мапа символ → бары.
+type alpacaCryptoBarsResponse struct {
+	Bars          map[string][]alpacaBar `json:"bars"`
+	NextPageToken string                 `json:"next_page_token"`
+}
+
+func (p *alpacaProvider) fetchCryptoBars(ctx context.Context, symbol string, start, end time.Time) ([]DailyRate, error) {
+	base := fmt.Sprintf("%s/v1beta3/crypto/us/bars", alpacaDataBaseURL)
+
+	var bars []alpacaBar
+	pageToken := ""
+	for {
+		q := url.Values{}
+		q.Set("symbols", symbol)
+		q.Set("timeframe", "1Day")
+		q.Set("start", start.Format(time.RFC3339))
+		q.Set("end", end.Format(time.RFC3339))
+		q.Set("limit", strconv.Itoa(alpacaMaxPageSize))
+		if pageToken != "" {
+			q.Set("page_token", pageToken)
+		}
+
+		var page alpacaCryptoBarsResponse
+		if err := p.getJSON(ctx, base+"?"+q.Encode(), &page); err != nil {
+			return nil, err
+		}
+		bars = append(bars, page.Bars[symbol]...)
+
+		if page.NextPageToken == "" {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+
+	return barsToDailyRates(symbol, bars)
+}
+
+func barsToDailyRates(symbol string, bars []alpacaBar) ([]DailyRate, error) {
+	if len(bars) == 0 {
+		return nil, fmt.Errorf("no bars returned for %s", symbol)
+	}
+
+	rates := make([]DailyRate, 0, len(bars))
+	for _, b := range bars {
+		// Alpaca отдаёт t в RFC3339, дневной бар нас интересует только по дате.
+		date := b.Timestamp
+		if t, err := time.Parse(time.RFC3339, b.Timestamp); err == nil {
+			date = t.Format("2006-01-02")
+		}
+		rates = append(rates, DailyRate{Date: date, Rate: b.Close})
+	}
+
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].Date < rates[j].Date
+	})
+
+	return rates, nil
+}
+
+// getJSON выполняет GET-запрос к Alpaca с экспоненциальным backoff при 429/5xx,
+// уважая заголовок Retry-After, если сервер его присылает.
+func (p *alpacaProvider) getJSON(ctx context.Context, rawURL string, out interface{}) error {
+	var lastErr error
+
+	for attempt := 0; attempt < alpacaMaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(lastErr.(retryAfterError).wait(attempt)):
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+		if err != nil {
+			return fmt.Errorf("create alpaca request: %w", err)
+		}
+		req.Header.Set("APCA-API-KEY-ID", p.keyID)
+		req.Header.Set("APCA-API-SECRET-KEY", p.secretKey)
+
+		resp, err := p.httpClient.Do(req)
+		if err != nil {
+			lastErr = retryAfterError{err: fmt.Errorf("call alpaca: %w", err)}
+			continue
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			defer resp.Body.Close()
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				return fmt.Errorf("decode alpaca response: %w", err)
+			}
+			return nil
+		}
+
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+		resp.Body.Close()
+
+		lastErr = retryAfterError{
+			err:        fmt.Errorf("alpaca returned status %d", resp.StatusCode),
+			retryAfter: retryAfter,
+		}
+		if !retryable {
+			return lastErr
+		}
+	}
+
+	return lastErr
+}
+
+// retryAfterError несёт ошибку попытки и, если сервер его прислал, явный
+// Retry-After; иначе используется экспоненциальный backoff с джиттером.
+type retryAfterError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e retryAfterError) Error() string { return e.err.Error() }
+
+func (e retryAfterError) wait(attempt int) time.Duration {
+	if e.retryAfter > 0 {
+		return e.retryAfter
+	}
+	backoff := time.Duration(1<<uint(attempt)) * 200 * time.Millisecond
+	jitter := time.Duration(rand.Int63n(int64(100 * time.Millisecond)))
+	return backoff + jitter
+}
+
+func parseRetryAfter(h string) time.Duration {
+	if h == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(strings.TrimSpace(h)); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	return 0
+}
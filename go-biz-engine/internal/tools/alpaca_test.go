@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want time.Duration
+	}{
+		{name: "empty header", in: "", want: 0},
+		{name: "seconds", in: "30", want: 30 * time.Second},
+		{name: "padded seconds", in: " 5 ", want: 5 * time.Second},
+		{name: "non-numeric (HTTP-date not supported)", in: "Wed, 21 Oct 2026 07:28:00 GMT", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.in); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBarsToDailyRates(t *testing.T) {
+	t.Run("empty bars is an error", func(t *testing.T) {
+		if _, err := barsToDailyRates("AAPL", nil); err == nil {
+			t.Fatal("expected error for empty bars, got nil")
+		}
+	})
+
+	t.Run("sorts by date and truncates timestamp to a date", func(t *testing.T) {
+		bars := []alpacaBar{
+			{Timestamp: "2026-01-03T05:00:00Z", Close: 103},
+			{Timestamp: "2026-01-01T05:00:00Z", Close: 101},
+			{Timestamp: "2026-01-02T05:00:00Z", Close: 102},
+		}
+
+		rates, err := barsToDailyRates("AAPL", bars)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := []DailyRate{
+			{Date: "2026-01-01", Rate: 101},
+			{Date: "2026-01-02", Rate: 102},
+			{Date: "2026-01-03", Rate: 103},
+		}
+		if len(rates) != len(want) {
+			t.Fatalf("len(rates) = %d, want %d", len(rates), len(want))
+		}
+		for i := range want {
+			if rates[i] != want[i] {
+				t.Errorf("rates[%d] = %+v, want %+v", i, rates[i], want[i])
+			}
+		}
+	})
+}
@@ -3,6 +3,8 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"strings"
 	"time"
 )
 
@@ -50,8 +52,32 @@ func decodeParams(params map[string]interface{}, target interface{}) error {
 	return json.Unmarshal(b, target)
 }
 
+// InvalidParamsError оборачивает ошибку, из-за которой Tool.Execute не смог
+// разобрать params запроса. ExecuteTool проверяет её через errors.As, чтобы
+// вернуть стабильный код "INVALID_PARAMS" вместо generic "<TOOL>_ERROR" —
+// эта же ошибка затем пробрасывается в JSON-RPC как codeInvalidParams
+// (internal/mcp.errorInfoToRPCError).
+type InvalidParamsError struct {
+	Err error
+}
+
+func (e *InvalidParamsError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InvalidParamsError) Unwrap() error {
+	return e.Err
+}
+
+// NewInvalidParamsError оборачивает err в *InvalidParamsError.
+func NewInvalidParamsError(err error) error {
+	return &InvalidParamsError{Err: err}
+}
+
 // ExecuteTool — центральная точка маршрутизации бизнес-логики tools.
-func ExecuteTool(ctx context.Context, req ExecuteRequest, engineVersion string) (ExecuteResponse, error) {
+// Конкретные инструменты ищутся в registry, что позволяет добавлять новые
+// tools без изменения этой функции.
+func ExecuteTool(ctx context.Context, req ExecuteRequest, engineVersion string, registry *Registry) (ExecuteResponse, error) {
 	start := time.Now()
 
 	resp := ExecuteResponse{
@@ -60,50 +86,54 @@ func ExecuteTool(ctx context.Context, req ExecuteRequest, engineVersion string)
 		EngineVersion: engineVersion,
 	}
 
-	switch req.ToolName {
-	case "financial_analyzer":
-		var fp FinancialParams
-		if err := decodeParams(req.Params, &fp); err != nil {
-			resp.Status = "error"
-			resp.Error = &ErrorInfo{
-				Code:    "INVALID_PARAMS",
-				Message: "invalid parameters for financial_analyzer: " + err.Error(),
-			}
-			break
-		}
-		// дефолт на всякий случай
-		if fp.Days <= 0 {
-			fp.Days = 30
+	tool, ok := registry.Get(req.ToolName)
+	if !ok {
+		resp.Status = "error"
+		resp.Error = &ErrorInfo{
+			Code:    "UNKNOWN_TOOL",
+			Message: "tool not supported: " + req.ToolName,
 		}
+		resp.Metrics.LatencyMs = time.Since(start).Milliseconds()
+		resp.Metrics.EngineTimeMs = resp.Metrics.LatencyMs
+		return resp, nil
+	}
 
-		result, err := ExecuteFinancialAnalyzer(ctx, fp)
-		if err != nil {
-			// бизнес-ошибка: вернём её в JSON, но не как 500
-			resp.Status = "error"
-			resp.Error = &ErrorInfo{
-				Code:    "FINANCIAL_ANALYZER_ERROR",
-				Message: err.Error(),
-			}
-			break
-		}
+	ctx, upstreamCalls := ensureUpstreamCallRecorder(ctx)
 
-		resp.Status = "success"
-		resp.Data = map[string]interface{}{
-			"rate_avg":   result.RateAvg,
-			"rate_min":   result.RateMin,
-			"rate_max":   result.RateMax,
-			"volatility": result.Volatility,
-			"raw":        result.Raw,
+	result, err := tool.Execute(ctx, req.Params)
+	resp.Metrics.UpstreamCalls = upstreamCalls.Total()
+	if err != nil {
+		// бизнес-ошибка: вернём её в JSON, но не как 500
+		code := strings.ToUpper(req.ToolName) + "_ERROR"
+		var invalidParams *InvalidParamsError
+		if errors.As(err, &invalidParams) {
+			code = "INVALID_PARAMS"
+		}
+		resp.Status = "error"
+		resp.Error = &ErrorInfo{
+			Code:    code,
+			Message: err.Error(),
 		}
+		resp.Metrics.LatencyMs = time.Since(start).Milliseconds()
+		resp.Metrics.EngineTimeMs = resp.Metrics.LatencyMs
+		return resp, nil
+	}
 
-	default:
+	data, err := toDataMap(result)
+	if err != nil {
 		resp.Status = "error"
 		resp.Error = &ErrorInfo{
-			Code:    "UNKNOWN_TOOL",
-			Message: "tool not supported: " + req.ToolName,
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
 		}
+		resp.Metrics.LatencyMs = time.Since(start).Milliseconds()
+		resp.Metrics.EngineTimeMs = resp.Metrics.LatencyMs
+		return resp, nil
 	}
 
+	resp.Status = "success"
+	resp.Data = data
+
 	resp.Metrics.LatencyMs = time.Since(start).Milliseconds()
 	resp.Metrics.EngineTimeMs = resp.Metrics.LatencyMs
 	// resp.Metrics.UpstreamCalls можно заполнять отдельно внутри конкретных tools
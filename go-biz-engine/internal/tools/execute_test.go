@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// fakeTool — минимальная реализация Tool для тестов ExecuteTool, не трогающая
+// реальные провайдеры/кэш.
+type fakeTool struct {
+	name string
+	err  error
+	data map[string]interface{}
+}
+
+func (t *fakeTool) Name() string                  { return t.name }
+func (t *fakeTool) ParamsSchema() json.RawMessage { return json.RawMessage(`{}`) }
+
+func (t *fakeTool) Execute(ctx context.Context, rawParams map[string]interface{}) (interface{}, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	return t.data, nil
+}
+
+func TestExecuteTool_ErrorCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		toolErr  error
+		wantCode string
+	}{
+		{
+			name:     "invalid params error keeps stable INVALID_PARAMS code",
+			toolErr:  NewInvalidParamsError(errors.New("json: cannot unmarshal")),
+			wantCode: "INVALID_PARAMS",
+		},
+		{
+			name:     "business error falls back to <TOOL>_ERROR",
+			toolErr:  errors.New("no rates returned"),
+			wantCode: "FAKE_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			registry.Register(&fakeTool{name: "fake", err: tt.toolErr})
+
+			resp, err := ExecuteTool(context.Background(), ExecuteRequest{ToolName: "fake"}, "test", registry)
+			if err != nil {
+				t.Fatalf("ExecuteTool returned transport error: %v", err)
+			}
+			if resp.Status != "error" {
+				t.Fatalf("Status = %q, want error", resp.Status)
+			}
+			if resp.Error == nil || resp.Error.Code != tt.wantCode {
+				t.Fatalf("Error.Code = %+v, want %q", resp.Error, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestExecuteTool_UnknownTool(t *testing.T) {
+	registry := NewRegistry()
+
+	resp, err := ExecuteTool(context.Background(), ExecuteRequest{ToolName: "missing"}, "test", registry)
+	if err != nil {
+		t.Fatalf("ExecuteTool returned transport error: %v", err)
+	}
+	if resp.Error == nil || resp.Error.Code != "UNKNOWN_TOOL" {
+		t.Fatalf("Error.Code = %+v, want UNKNOWN_TOOL", resp.Error)
+	}
+}
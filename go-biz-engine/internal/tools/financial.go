@@ -5,17 +5,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
-	"net/http"
-	"sort"
 	"strings"
-	"time"
+
+	"go-biz-engine/internal/cache"
+	"go-biz-engine/internal/config"
 )
 
 type FinancialParams struct {
-	BaseCurrency  string  `json:"base_currency"`          // например "USD"
-	QuoteCurrency string  `json:"quote_currency"`         // например "EUR"
-	Days          int     `json:"days"`                   // период в днях (N последних дней)
-	Amount        float64 `json:"amount,omitempty"`       // опционально, пока не используем
+	BaseCurrency  string  `json:"base_currency"`            // например "USD"
+	QuoteCurrency string  `json:"quote_currency"`           // например "EUR"
+	Days          int     `json:"days"`                     // период в днях (N последних дней)
+	Amount        float64 `json:"amount,omitempty"`         // опционально, пока не используем
+	Provider      string  `json:"provider,omitempty"`       // "frankfurter" (по умолчанию) | "alpaca"
+	RiskFreeRate  float64 `json:"risk_free_rate,omitempty"` // годовая безрисковая ставка (доля), для Sharpe ratio
+	Windows       []int   `json:"windows,omitempty"`        // окна для SMA/EMA, например [7, 30]
 }
 
 type DailyRate struct {
@@ -27,25 +30,21 @@ type FinancialResult struct {
 	RateAvg    float64     `json:"rate_avg"`
 	RateMin    float64     `json:"rate_min"`
 	RateMax    float64     `json:"rate_max"`
-	Volatility float64     `json:"volatility"` // стандартное отклонение дневных курсов
+	Volatility float64     `json:"volatility"` // стандартное отклонение дневных курсов (как и раньше)
 	Raw        []DailyRate `json:"raw"`        // сырые данные по дням
-}
-
-const frankfurterBaseURL = "https://api.frankfurter.dev"
-
-var httpClient = &http.Client{
-	Timeout: 10 *time.Second,
-}
 
-// структура ответа Frankfurter для тайм-серий
-type frankfurterTimeSeriesResponse struct {
-	Base      string                        `json:"base"`
-	StartDate string                        `json:"start_date"`
-	EndDate   string                        `json:"end_date"`
-	Rates     map[string]map[string]float64 `json:"rates"` // "2024-01-02": { "USD": 1.09 }
+	// Более содержательные количественные метрики поверх дневных log-returns.
+	// Указатели, а не float64: при нехватке данных или нулевой дисперсии
+	// возвращаем null, а не NaN/Inf, который ломает сериализацию в JSON.
+	AnnualizedVolatility *float64        `json:"annualized_volatility"` // σ(log-returns) * sqrt(252)
+	SharpeRatio          *float64        `json:"sharpe_ratio"`
+	MaxDrawdown          *float64        `json:"max_drawdown"`
+	MovingAverages       []MovingAverage `json:"moving_averages,omitempty"`
 }
 
-func ExecuteFinancialAnalyzer(ctx context.Context, params FinancialParams) (FinancialResult, error) {
+// ExecuteFinancialAnalyzer считает метрики по дневным котировкам, полученным
+// от выбранного в params.Provider RateProvider.
+func ExecuteFinancialAnalyzer(ctx context.Context, params FinancialParams, providers map[string]RateProvider) (FinancialResult, error) {
 	base := strings.ToUpper(strings.TrimSpace(params.BaseCurrency))
 	quote := strings.ToUpper(strings.TrimSpace(params.QuoteCurrency))
 
@@ -56,59 +55,19 @@ func ExecuteFinancialAnalyzer(ctx context.Context, params FinancialParams) (Fina
 		return FinancialResult{}, fmt.Errorf("days must be > 0")
 	}
 
-	// считаем период: от сегодня - (days-1) до сегодня (UTC)
-	end := time.Now().UTC()
-	start := end.AddDate(0, 0, -params.Days+1)
-
-	startStr := start.Format("2006-01-02")
-	endStr := end.Format("2006-01-02")
-
-	// пример: /v1/2024-01-01..2024-01-31?base=USD&symbols=EUR
-	url := fmt.Sprintf("%s/v1/%s..%s?base=%s&symbols=%s",
-		frankfurterBaseURL, startStr, endStr, base, quote)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	provider, err := resolveProvider(params.Provider, providers)
 	if err != nil {
-		return FinancialResult{}, fmt.Errorf("create request: %w", err)
+		return FinancialResult{}, err
 	}
 
-	resp, err := httpClient.Do(req)
+	rates, err := provider.FetchDailyRates(ctx, base, quote, params.Days)
 	if err != nil {
-		return FinancialResult{}, fmt.Errorf("call frankfurter: %w", err)
+		return FinancialResult{}, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return FinancialResult{}, fmt.Errorf("frankfurter returned status %d", resp.StatusCode)
-	}
-
-	var apiResp frankfurterTimeSeriesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return FinancialResult{}, fmt.Errorf("decode frankfurter response: %w", err)
-	}
-
-	// вытаскиваем список DailyRate
-	rates := make([]DailyRate, 0, len(apiResp.Rates))
-	for date, m := range apiResp.Rates {
-		rate, ok := m[quote]
-		if !ok {
-			continue
-		}
-		rates = append(rates, DailyRate{
-			Date: date,
-			Rate: rate,
-		})
-	}
-
 	if len(rates) == 0 {
 		return FinancialResult{}, fmt.Errorf("no rates returned for %s/%s", base, quote)
 	}
 
-	// сортируем по дате (строка в ISO-формате, поэтому лексикографический порядок == хронологический)
-	sort.Slice(rates, func(i, j int) bool {
-		return rates[i].Date < rates[j].Date
-	})
-
 	// считаем метрики
 	sum := 0.0
 	min := rates[0].Rate
@@ -136,13 +95,77 @@ func ExecuteFinancialAnalyzer(ctx context.Context, params FinancialParams) (Fina
 	}
 	volatility := math.Sqrt(varSum / n)
 
+	returns := logReturns(rates)
+
 	result := FinancialResult{
-		RateAvg:    avg,
-		RateMin:    min,
-		RateMax:    max,
-		Volatility: volatility,
-		Raw:        rates,
+		RateAvg:              avg,
+		RateMin:              min,
+		RateMax:              max,
+		Volatility:           volatility,
+		Raw:                  rates,
+		AnnualizedVolatility: annualizedVolatility(returns),
+		SharpeRatio:          sharpeRatio(returns, params.RiskFreeRate),
+		MaxDrawdown:          maxDrawdown(rates),
+		MovingAverages:       movingAverages(rates, params.Windows),
 	}
 
 	return result, nil
 }
+
+// financialAnalyzerParamsSchema — JSON Schema параметров financial_analyzer
+// для реестра инструментов (GET /tools).
+const financialAnalyzerParamsSchema = `{
+	"type": "object",
+	"properties": {
+		"base_currency": {"type": "string", "description": "базовая валюта/тикер, например USD или AAPL"},
+		"quote_currency": {"type": "string", "description": "котируемая валюта, например EUR или USD"},
+		"days": {"type": "integer", "minimum": 1, "description": "период в днях (N последних дней)"},
+		"amount": {"type": "number", "description": "опционально, пока не используется"},
+		"provider": {"type": "string", "enum": ["frankfurter", "alpaca"], "description": "источник котировок, по умолчанию frankfurter"},
+		"risk_free_rate": {"type": "number", "description": "годовая безрисковая ставка (доля), для Sharpe ratio"},
+		"windows": {"type": "array", "items": {"type": "integer", "minimum": 1}, "description": "окна для SMA/EMA, например [7, 30]"}
+	},
+	"required": ["base_currency", "quote_currency"]
+}`
+
+// financialAnalyzerTool оборачивает ExecuteFinancialAnalyzer под интерфейс Tool.
+// providers собираются один раз при создании тула из config.Config.
+type financialAnalyzerTool struct {
+	providers map[string]RateProvider
+}
+
+func newFinancialAnalyzerTool(cfg *config.Config) *financialAnalyzerTool {
+	rateCache := cache.New(cfg.RateCacheTTL)
+	httpClient := newUpstreamHTTPClient(cfg)
+
+	frankfurter := newFrankfurterProvider(httpClient, rateCache)
+	alpaca := newAlpacaProvider(cfg.AlpacaAPIKeyID, cfg.AlpacaAPISecretKey, httpClient, rateCache)
+
+	return &financialAnalyzerTool{
+		providers: map[string]RateProvider{
+			frankfurter.Name(): frankfurter,
+			alpaca.Name():      alpaca,
+		},
+	}
+}
+
+func (t *financialAnalyzerTool) Name() string {
+	return "financial_analyzer"
+}
+
+func (t *financialAnalyzerTool) ParamsSchema() json.RawMessage {
+	return json.RawMessage(financialAnalyzerParamsSchema)
+}
+
+func (t *financialAnalyzerTool) Execute(ctx context.Context, rawParams map[string]interface{}) (interface{}, error) {
+	var fp FinancialParams
+	if err := decodeParams(rawParams, &fp); err != nil {
+		return nil, NewInvalidParamsError(fmt.Errorf("invalid parameters for financial_analyzer: %w", err))
+	}
+	// дефолт на всякий случай
+	if fp.Days <= 0 {
+		fp.Days = 30
+	}
+
+	return ExecuteFinancialAnalyzer(ctx, fp, t.providers)
+}
@@ -0,0 +1,32 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestFinancialResult_NullMetricsAreSerialized guards against quant fields
+// silently vanishing from the JSON body: json.Marshal on a nil *float64 with
+// "omitempty" drops the key entirely instead of emitting null.
+func TestFinancialResult_NullMetricsAreSerialized(t *testing.T) {
+	b, err := json.Marshal(FinancialResult{})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	for _, field := range []string{"annualized_volatility", "sharpe_ratio", "max_drawdown"} {
+		v, ok := got[field]
+		if !ok {
+			t.Errorf("field %q is missing from JSON output, want present with value null", field)
+			continue
+		}
+		if v != nil {
+			t.Errorf("field %q = %v, want null", field, v)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"go-biz-engine/internal/cache"
+)
+
+const frankfurterBaseURL = "https://api.frankfurter.dev"
+
+// структура ответа Frankfurter для тайм-серий
+type frankfurterTimeSeriesResponse struct {
+	Base      string                        `json:"base"`
+	StartDate string                        `json:"start_date"`
+	EndDate   string                        `json:"end_date"`
+	Rates     map[string]map[string]float64 `json:"rates"` // "2024-01-02": { "USD": 1.09 }
+}
+
+// frankfurterProvider — RateProvider поверх Frankfurter (курсы валют, без ключа API).
+// Ответы кэшируются по URL в rateCache, чтобы конкурентные запросы на одно
+// и то же окно (base, quote, days) не долбили апстрим повторно.
+type frankfurterProvider struct {
+	httpClient *http.Client
+	rateCache  *cache.Cache
+}
+
+func newFrankfurterProvider(httpClient *http.Client, rateCache *cache.Cache) *frankfurterProvider {
+	return &frankfurterProvider{httpClient: httpClient, rateCache: rateCache}
+}
+
+func (p *frankfurterProvider) Name() string {
+	return providerFrankfurter
+}
+
+func (p *frankfurterProvider) FetchDailyRates(ctx context.Context, base, quote string, days int) ([]DailyRate, error) {
+	// считаем период: от сегодня - (days-1) до сегодня (UTC)
+	end := time.Now().UTC()
+	start := end.AddDate(0, 0, -days+1)
+
+	startStr := start.Format("2006-01-02")
+	endStr := end.Format("2006-01-02")
+
+	// пример: /v1/2024-01-01..2024-01-31?base=USD&symbols=EUR
+	url := fmt.Sprintf("%s/v1/%s..%s?base=%s&symbols=%s",
+		frankfurterBaseURL, startStr, endStr, base, quote)
+
+	v, _, err := p.rateCache.GetOrLoad(url, func() (interface{}, error) {
+		recordUpstreamCall(ctx, p.Name())
+		return p.fetch(ctx, url, base, quote)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]DailyRate), nil
+}
+
+func (p *frankfurterProvider) fetch(ctx context.Context, url, base, quote string) ([]DailyRate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("call frankfurter: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("frankfurter returned status %d", resp.StatusCode)
+	}
+
+	var apiResp frankfurterTimeSeriesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("decode frankfurter response: %w", err)
+	}
+
+	// вытаскиваем список DailyRate
+	rates := make([]DailyRate, 0, len(apiResp.Rates))
+	for date, m := range apiResp.Rates {
+		rate, ok := m[quote]
+		if !ok {
+			continue
+		}
+		rates = append(rates, DailyRate{
+			Date: date,
+			Rate: rate,
+		})
+	}
+
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("no rates returned for %s/%s", base, quote)
+	}
+
+	// сортируем по дате (строка в ISO-формате, поэтому лексикографический порядок == хронологический)
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].Date < rates[j].Date
+	})
+
+	return rates, nil
+}
@@ -0,0 +1,13 @@
+package tools
+
+import (
+	"net/http"
+
+	"go-biz-engine/internal/config"
+)
+
+// newUpstreamHTTPClient строит HTTP-клиент для апстрим-провайдеров котировок
+// с таймаутом из конфига, вместо захардкоженного глобального клиента.
+func newUpstreamHTTPClient(cfg *config.Config) *http.Client {
+	return &http.Client{Timeout: cfg.UpstreamTimeout}
+}
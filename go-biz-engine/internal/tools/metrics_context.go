@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"context"
+	"sync"
+)
+
+type ctxKey int
+
+const upstreamCallsCtxKey ctxKey = 0
+
+// UpstreamCallRecorder считает реальные (не из internal/cache) апстрим-вызовы
+// за время одного /execute-tool, с разбивкой по провайдеру. Это позволяет
+// и ExecuteResponse.Metrics.UpstreamCalls, и Prometheus-счётчики в
+// internal/http оставаться согласованными — оба читают один и тот же recorder.
+type UpstreamCallRecorder struct {
+	mu         sync.Mutex
+	byProvider map[string]int64
+}
+
+// NewUpstreamCallRecorder создаёт пустой recorder.
+func NewUpstreamCallRecorder() *UpstreamCallRecorder {
+	return &UpstreamCallRecorder{byProvider: make(map[string]int64)}
+}
+
+func (r *UpstreamCallRecorder) record(provider string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byProvider[provider]++
+}
+
+// Total возвращает суммарное число апстрим-вызовов по всем провайдерам.
+func (r *UpstreamCallRecorder) Total() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var total int64
+	for _, n := range r.byProvider {
+		total += n
+	}
+	return int(total)
+}
+
+// ByProvider возвращает копию счётчиков по провайдерам.
+func (r *UpstreamCallRecorder) ByProvider() map[string]int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make(map[string]int64, len(r.byProvider))
+	for k, v := range r.byProvider {
+		out[k] = v
+	}
+	return out
+}
+
+// ContextWithUpstreamCallRecorder кладёт recorder в ctx. Используется
+// internal/http, чтобы после ExecuteTool прочитать разбивку по провайдерам
+// для Prometheus-метрик.
+func ContextWithUpstreamCallRecorder(ctx context.Context, r *UpstreamCallRecorder) context.Context {
+	return context.WithValue(ctx, upstreamCallsCtxKey, r)
+}
+
+// ensureUpstreamCallRecorder возвращает recorder из ctx, если он там уже
+// есть (положен вызывающим кодом через ContextWithUpstreamCallRecorder),
+// иначе создаёт новый — ExecuteTool всегда должен иметь recorder, чтобы
+// заполнить Metrics.UpstreamCalls, даже если caller не интересуется разбивкой.
+func ensureUpstreamCallRecorder(ctx context.Context) (context.Context, *UpstreamCallRecorder) {
+	if r, ok := ctx.Value(upstreamCallsCtxKey).(*UpstreamCallRecorder); ok {
+		return ctx, r
+	}
+	r := NewUpstreamCallRecorder()
+	return ContextWithUpstreamCallRecorder(ctx, r), r
+}
+
+// recordUpstreamCall увеличивает счётчик апстрим-вызовов для provider, если
+// recorder есть в ctx. Providers вызывают это только когда реально сходили
+// в сеть, а не отдали значение из internal/cache.
+func recordUpstreamCall(ctx context.Context, provider string) {
+	if r, ok := ctx.Value(upstreamCallsCtxKey).(*UpstreamCallRecorder); ok {
+		r.record(provider)
+	}
+}
@@ -0,0 +1,37 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+)
+
+// RateProvider абстрагирует источник дневных цен закрытия, чтобы
+// financial_analyzer мог считать метрики не только по FX (Frankfurter),
+// но и по акциям/крипте (Alpaca) без изменения логики анализа.
+type RateProvider interface {
+	// Name — идентификатор провайдера, совпадает со значением FinancialParams.Provider.
+	Name() string
+	// FetchDailyRates возвращает дневные цены (курс или цена закрытия) quote
+	// относительно base за последние days дней, отсортированные по дате
+	// по возрастанию.
+	FetchDailyRates(ctx context.Context, base, quote string, days int) ([]DailyRate, error)
+}
+
+const (
+	providerFrankfurter = "frankfurter"
+	providerAlpaca      = "alpaca"
+)
+
+// resolveProvider выбирает RateProvider по имени из FinancialParams.Provider.
+// Пустое имя — дефолт на frankfurter, чтобы существующие клиенты не ломались.
+func resolveProvider(name string, providers map[string]RateProvider) (RateProvider, error) {
+	if name == "" {
+		name = providerFrankfurter
+	}
+
+	p, ok := providers[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported provider: %s", name)
+	}
+	return p, nil
+}
@@ -0,0 +1,146 @@
+package tools
+
+import "math"
+
+const tradingDaysPerYear = 252
+
+// MovingAverage — простое и экспоненциальное скользящее среднее по
+// последним Window точкам ряда. SMA/EMA = nil, если данных меньше Window
+// (а не NaN/Inf — это не сериализуется в валидный JSON).
+type MovingAverage struct {
+	Window int      `json:"window"`
+	SMA    *float64 `json:"sma"`
+	EMA    *float64 `json:"ema"`
+}
+
+// logReturns считает дневные логарифмические доходности r_i = ln(P_i/P_{i-1})
+// по отсортированному по дате ряду rates. Нужно минимум 2 точки.
+func logReturns(rates []DailyRate) []float64 {
+	if len(rates) < 2 {
+		return nil
+	}
+
+	returns := make([]float64, 0, len(rates)-1)
+	for i := 1; i < len(rates); i++ {
+		prev := rates[i-1].Rate
+		if prev == 0 {
+			continue
+		}
+		returns = append(returns, math.Log(rates[i].Rate/prev))
+	}
+	return returns
+}
+
+func mean(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+func stdev(xs []float64, m float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	var varSum float64
+	for _, x := range xs {
+		d := x - m
+		varSum += d * d
+	}
+	return math.Sqrt(varSum / float64(len(xs)))
+}
+
+// annualizedVolatility считает σ(log-returns) * sqrt(252), nil если точек
+// недостаточно, чтобы посчитать хотя бы одну доходность.
+func annualizedVolatility(returns []float64) *float64 {
+	if len(returns) == 0 {
+		return nil
+	}
+	v := stdev(returns, mean(returns)) * math.Sqrt(float64(tradingDaysPerYear))
+	return &v
+}
+
+// sharpeRatio = (mean(r)*252 - rf) / (stdev(r)*sqrt(252)); nil, если
+// доходностей недостаточно или их стандартное отклонение равно нулю
+// (деление на ноль дало бы NaN/Inf, что не сериализуется в JSON).
+func sharpeRatio(returns []float64, riskFreeRate float64) *float64 {
+	if len(returns) == 0 {
+		return nil
+	}
+
+	m := mean(returns)
+	sd := stdev(returns, m)
+	if sd == 0 {
+		return nil
+	}
+
+	s := (m*float64(tradingDaysPerYear) - riskFreeRate) / (sd * math.Sqrt(float64(tradingDaysPerYear)))
+	return &s
+}
+
+// maxDrawdown = max_t (max_{s<=t} P_s - P_t) / max_{s<=t} P_s по отсортированному
+// по дате ряду rates. Нужно минимум 2 точки.
+func maxDrawdown(rates []DailyRate) *float64 {
+	if len(rates) < 2 {
+		return nil
+	}
+
+	peak := rates[0].Rate
+	var worst float64
+	for _, r := range rates {
+		if r.Rate > peak {
+			peak = r.Rate
+		}
+		if peak == 0 {
+			continue
+		}
+		dd := (peak - r.Rate) / peak
+		if dd > worst {
+			worst = dd
+		}
+	}
+	return &worst
+}
+
+// movingAverages считает SMA/EMA по последним Window точкам ряда rates для
+// каждого окна из windows. Окно, для которого точек не хватает, всё равно
+// попадает в результат, но с SMA/EMA = nil. Окна <= 0 пропускаются и в
+// результат не попадают.
+func movingAverages(rates []DailyRate, windows []int) []MovingAverage {
+	out := make([]MovingAverage, 0, len(windows))
+
+	for _, w := range windows {
+		if w <= 0 {
+			continue
+		}
+
+		ma := MovingAverage{Window: w}
+		if len(rates) >= w {
+			tail := rates[len(rates)-w:]
+
+			vals := make([]float64, len(tail))
+			for i, r := range tail {
+				vals[i] = r.Rate
+			}
+			sma := mean(vals)
+			ma.SMA = &sma
+
+			// EMA по тому же окну: затравка — уже посчитанная SMA, затем
+			// стандартный множитель сглаживания alpha = 2/(window+1).
+			alpha := 2.0 / (float64(w) + 1)
+			ema := sma
+			for _, v := range vals[1:] {
+				ema = alpha*v + (1-alpha)*ema
+			}
+			ma.EMA = &ema
+		}
+
+		out = append(out, ma)
+	}
+
+	return out
+}
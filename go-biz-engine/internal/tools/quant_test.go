@@ -0,0 +1,109 @@
+package tools
+
+import (
+	"math"
+	"testing"
+)
+
+func rates(values ...float64) []DailyRate {
+	out := make([]DailyRate, len(values))
+	for i, v := range values {
+		out[i] = DailyRate{Date: string(rune('A' + i)), Rate: v}
+	}
+	return out
+}
+
+func TestLogReturns(t *testing.T) {
+	t.Run("fewer than 2 points is nil", func(t *testing.T) {
+		if got := logReturns(rates(1.0)); got != nil {
+			t.Fatalf("logReturns = %v, want nil", got)
+		}
+	})
+
+	t.Run("computes ln(P_i/P_i-1)", func(t *testing.T) {
+		got := logReturns(rates(1.0, 2.0, 1.0))
+		want := []float64{math.Log(2), math.Log(0.5)}
+		if len(got) != len(want) {
+			t.Fatalf("len(got) = %d, want %d", len(got), len(want))
+		}
+		for i := range want {
+			if math.Abs(got[i]-want[i]) > 1e-12 {
+				t.Errorf("got[%d] = %v, want %v", i, got[i], want[i])
+			}
+		}
+	})
+}
+
+func TestAnnualizedVolatility(t *testing.T) {
+	if got := annualizedVolatility(nil); got != nil {
+		t.Fatalf("annualizedVolatility(nil) = %v, want nil", got)
+	}
+
+	returns := []float64{0.01, -0.01, 0.02, -0.02}
+	got := annualizedVolatility(returns)
+	if got == nil {
+		t.Fatal("annualizedVolatility = nil, want non-nil")
+	}
+	want := stdev(returns, mean(returns)) * math.Sqrt(tradingDaysPerYear)
+	if math.Abs(*got-want) > 1e-12 {
+		t.Errorf("annualizedVolatility = %v, want %v", *got, want)
+	}
+}
+
+func TestSharpeRatio(t *testing.T) {
+	if got := sharpeRatio(nil, 0); got != nil {
+		t.Fatalf("sharpeRatio(nil) = %v, want nil", got)
+	}
+
+	t.Run("zero stdev is nil, not Inf/NaN", func(t *testing.T) {
+		if got := sharpeRatio([]float64{0.01, 0.01, 0.01}, 0); got != nil {
+			t.Fatalf("sharpeRatio = %v, want nil", got)
+		}
+	})
+}
+
+func TestMaxDrawdown(t *testing.T) {
+	if got := maxDrawdown(rates(1.0)); got != nil {
+		t.Fatalf("maxDrawdown(1 point) = %v, want nil", got)
+	}
+
+	got := maxDrawdown(rates(100, 120, 90, 110))
+	if got == nil {
+		t.Fatal("maxDrawdown = nil, want non-nil")
+	}
+	want := (120.0 - 90.0) / 120.0
+	if math.Abs(*got-want) > 1e-12 {
+		t.Errorf("maxDrawdown = %v, want %v", *got, want)
+	}
+}
+
+func TestMovingAverages(t *testing.T) {
+	r := rates(1, 2, 3, 4, 5)
+
+	mas := movingAverages(r, []int{3, 10})
+	if len(mas) != 2 {
+		t.Fatalf("len(mas) = %d, want 2", len(mas))
+	}
+
+	if mas[0].Window != 3 || mas[0].SMA == nil {
+		t.Fatalf("window 3: %+v, want non-nil SMA", mas[0])
+	}
+	wantSMA := (3.0 + 4.0 + 5.0) / 3.0
+	if math.Abs(*mas[0].SMA-wantSMA) > 1e-12 {
+		t.Errorf("SMA = %v, want %v", *mas[0].SMA, wantSMA)
+	}
+
+	// EMA сидируется от SMA того же окна (alpha = 2/(window+1) = 0.5 здесь),
+	// затем сглаживается по остальным точкам окна: 4 -> 4 -> 4.5.
+	if mas[0].EMA == nil {
+		t.Fatalf("window 3: %+v, want non-nil EMA", mas[0])
+	}
+	wantEMA := 4.5
+	if math.Abs(*mas[0].EMA-wantEMA) > 1e-12 {
+		t.Errorf("EMA = %v, want %v", *mas[0].EMA, wantEMA)
+	}
+
+	if mas[1].Window != 10 || mas[1].SMA != nil || mas[1].EMA != nil {
+		t.Fatalf("window larger than series: %+v, want nil SMA/EMA", mas[1])
+	}
+}
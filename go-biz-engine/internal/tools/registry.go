@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"go-biz-engine/internal/config"
+)
+
+// Tool — единый интерфейс для бизнес-инструментов, которые можно вызвать
+// через /execute-tool. Реализации регистрируются в Registry при старте.
+type Tool interface {
+	// Name — идентификатор инструмента, совпадает с ExecuteRequest.ToolName.
+	Name() string
+	// ParamsSchema возвращает JSON Schema параметров инструмента, чтобы
+	// LLM/MCP-клиенты могли понять, какие поля ожидаются.
+	ParamsSchema() json.RawMessage
+	// Execute выполняет инструмент с "сырыми" параметрами запроса
+	// (map[string]interface{} из ExecuteRequest.Params) и возвращает
+	// результат, который будет сериализован в ExecuteResponse.Data.
+	Execute(ctx context.Context, rawParams map[string]interface{}) (interface{}, error)
+}
+
+// Registry хранит зарегистрированные Tool по имени.
+type Registry struct {
+	mu    sync.RWMutex
+	tools map[string]Tool
+}
+
+// NewRegistry создаёт пустой реестр инструментов.
+func NewRegistry() *Registry {
+	return &Registry{tools: make(map[string]Tool)}
+}
+
+// Register добавляет инструмент в реестр. Повторная регистрация под тем же
+// именем перезаписывает предыдущую реализацию — это осознанный выбор, чтобы
+// тесты и плагины могли подменять инструменты.
+func (r *Registry) Register(t Tool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tools[t.Name()] = t
+}
+
+// Get возвращает инструмент по имени.
+func (r *Registry) Get(name string) (Tool, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.tools[name]
+	return t, ok
+}
+
+// List возвращает все зарегистрированные инструменты в произвольном порядке.
+func (r *Registry) List() []Tool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]Tool, 0, len(r.tools))
+	for _, t := range r.tools {
+		out = append(out, t)
+	}
+	return out
+}
+
+// NewDefaultRegistry собирает реестр со всеми встроенными инструментами.
+// Используется при старте сервиса в internal/http.NewHandler.
+func NewDefaultRegistry(cfg *config.Config) *Registry {
+	r := NewRegistry()
+	r.Register(newFinancialAnalyzerTool(cfg))
+	return r
+}
+
+// toDataMap приводит произвольный результат Tool.Execute к
+// map[string]interface{}, в котором привыкли работать ExecuteResponse.Data
+// и потребители /execute-tool.
+func toDataMap(result interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal tool result: %w", err)
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal tool result: %w", err)
+	}
+	return data, nil
+}